@@ -0,0 +1,87 @@
+package httperrors
+
+import "net/http"
+
+// Combine composes several errors into a single HTTPError, for handlers that
+// fan out to multiple backends and need to report every failure at once.
+//
+// Combine returns nil if every input is nil, and returns the single error
+// (coerced via ToHTTPError) unchanged if only one input is non-nil. Otherwise
+// it returns an HTTPError whose Error() lists each child's Message() on its
+// own line, whose ResponseCode() follows a fixed policy (the highest 5xx
+// among the children wins, else the highest 4xx, else UninitializedResponseCode),
+// and whose ErrorCode() is that of the child that decided ResponseCode().
+// Retriable() is true only if every child is retriable. The children are
+// available via Errors(), and errors.Is/errors.As walk each of them.
+func Combine(errs ...error) HTTPError {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+
+	if len(nonNil) == 0 {
+		return nil
+	}
+	if len(nonNil) == 1 {
+		return ToHTTPError(nonNil[0])
+	}
+
+	retriable := true
+	best4xx := UninitializedResponseCode
+	best5xx := UninitializedResponseCode
+
+	for _, err := range nonNil {
+		httpErr := ToHTTPError(err)
+		if !httpErr.Retriable() {
+			retriable = false
+		}
+
+		switch code := httpErr.ResponseCode(); {
+		case code >= http.StatusInternalServerError && code > best5xx:
+			best5xx = code
+		case code >= http.StatusBadRequest && code < http.StatusInternalServerError && code > best4xx:
+			best4xx = code
+		}
+	}
+
+	respCode := UninitializedResponseCode
+	if best5xx != UninitializedResponseCode {
+		respCode = best5xx
+	} else if best4xx != UninitializedResponseCode {
+		respCode = best4xx
+	}
+
+	errCode := UninitializedErrorCode
+	for _, err := range nonNil {
+		if httpErr := ToHTTPError(err); httpErr.ResponseCode() == respCode {
+			errCode = httpErr.ErrorCode()
+			break
+		}
+	}
+
+	// Chain the children's messages into a real baseHTTPError chain, one node
+	// per child, so Message()/MarshalJSON see a single outer message and the
+	// remaining children as details instead of a pre-joined blob of text.
+	var inner error
+	for i := len(nonNil) - 1; i >= 1; i-- {
+		inner = &baseHTTPError{
+			msg:       ToHTTPError(nonNil[i]).Message(),
+			respCode:  UninitializedResponseCode,
+			retriable: true,
+			inner:     inner,
+		}
+	}
+
+	return &baseHTTPError{
+		msg:          ToHTTPError(nonNil[0]).Message(),
+		respCode:     respCode,
+		errCode:      errCode,
+		frames:       captureFrames(callersToSkip),
+		retriable:    retriable,
+		retriableSet: true,
+		inner:        inner,
+		children:     nonNil,
+	}
+}
@@ -0,0 +1,108 @@
+package httperrors
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Combine", func() {
+	It("returns nil if every input is nil", func() {
+		Expect(Combine(nil, nil)).To(BeNil())
+	})
+
+	It("returns the single non-nil input unchanged", func() {
+		err := NotFound("user 42")
+		Expect(Combine(nil, err, nil)).To(Equal(err))
+	})
+
+	It("lists each child on its own line", func() {
+		combined := Combine(NotFound("user 42"), Conflict("duplicate email"))
+		Expect(combined.Error()).To(Equal("user 42\nduplicate email"))
+	})
+
+	It("picks the highest 5xx response code when present", func() {
+		combined := Combine(NotFound("user 42"), ServiceUnavailable("backend down"), BadGateway("upstream err"))
+		Expect(combined.ResponseCode()).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	It("falls back to the highest 4xx response code", func() {
+		combined := Combine(NotFound("user 42"), Conflict("duplicate email"))
+		Expect(combined.ResponseCode()).To(Equal(http.StatusConflict))
+	})
+
+	It("is retriable only if every child is retriable", func() {
+		combined := Combine(NotFound("user 42").SetRetriable(false), Conflict("duplicate email"))
+		Expect(combined.Retriable()).To(BeFalse())
+
+		allRetriable := Combine(NotFound("user 42"), Conflict("duplicate email"))
+		Expect(allRetriable.Retriable()).To(BeTrue())
+	})
+
+	It("captures its own frames instead of the empty ones on its message chain", func() {
+		combined := Combine(NotFound("user 42"), Conflict("duplicate email"))
+		Expect(combined.Frames()).ToNot(BeEmpty())
+		Expect(combined.StackTrace()).ToNot(Equal(UninitializedStackTrace))
+	})
+
+	It("exposes the children via Errors", func() {
+		notFound := NotFound("user 42")
+		conflict := Conflict("duplicate email")
+		combined := Combine(notFound, conflict)
+		Expect(combined.Errors()).To(Equal([]error{notFound, conflict}))
+	})
+
+	Describe("JSON", func() {
+		It("marshals a single outer message with the rest as details", func() {
+			combined := Combine(NotFound("user 42"), Conflict("duplicate email"))
+
+			data, err := json.Marshal(combined)
+			Expect(err).ToNot(HaveOccurred())
+
+			var decoded map[string]interface{}
+			Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+			Expect(decoded["status"]).To(Equal(float64(http.StatusConflict)))
+			Expect(decoded["code"]).To(Equal("conflict"))
+			Expect(decoded["message"]).To(Equal("user 42"))
+			Expect(decoded["details"]).To(Equal([]interface{}{"duplicate email"}))
+		})
+
+		It("round-trips the same shape through WriteError", func() {
+			combined := Combine(NotFound("user 42"), Conflict("duplicate email"))
+
+			recorder := httptest.NewRecorder()
+			WriteError(recorder, combined)
+
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+
+			var decoded map[string]interface{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &decoded)).To(Succeed())
+			Expect(decoded["code"]).To(Equal("conflict"))
+			Expect(decoded["message"]).To(Equal("user 42"))
+			Expect(decoded["details"]).To(Equal([]interface{}{"duplicate email"}))
+		})
+	})
+
+	Describe("errors.Is and errors.As across children", func() {
+		It("matches a sentinel held by one of the children", func() {
+			combined := Combine(Wrap(sql.ErrNoRows, "loading user"), Conflict("duplicate email"))
+			Expect(errors.Is(combined, sql.ErrNoRows)).To(BeTrue())
+			Expect(errors.Is(combined, ErrNotFound)).To(BeFalse())
+		})
+
+		It("assigns a target found within a child", func() {
+			pathErr := &fs.PathError{Op: "open", Path: "users.db", Err: fs.ErrNotExist}
+			combined := Combine(Wrap(pathErr, "loading user"), Conflict("duplicate email"))
+
+			var target *fs.PathError
+			Expect(errors.As(combined, &target)).To(BeTrue())
+			Expect(target).To(Equal(pathErr))
+		})
+	})
+})
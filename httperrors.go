@@ -3,7 +3,6 @@ package httperrors
 import (
 	"fmt"
 	"net/http"
-	"runtime"
 	"strings"
 )
 
@@ -31,24 +30,46 @@ type HTTPError interface {
 	// ErrorCode gets the outermost error code
 	ErrorCode() string
 
-	// StackTrace gets the innermost available stacktrace
+	// StackTrace formats the frames returned by Frames as a string
 	StackTrace() string
 
+	// Frames gets the frames of the outermost error in the wrap chain that recorded any
+	Frames() []Frame
+
+	// SetFrames overrides the stack frames recorded for this HTTPError. This is
+	// mainly useful for callers that capture frames themselves (e.g. a panic
+	// recovery middleware capturing the frames at the panic site) and need to
+	// attach them after construction
+	SetFrames(frames []Frame) HTTPError
+
 	// SetRetriable sets if this error is retriable
 	SetRetriable(retriable bool) HTTPError
 
 	// Retriable checks if the error could be addressed by retrying the request. All
 	// errors are considered retriable by default unless otherwise specified
 	Retriable() bool
+
+	// SetPublicMessage sets a public-facing message that WriteError and MarshalJSON
+	// use in place of the full message chain, so servers can hide the internal
+	// message chain from external clients while still logging it in full
+	SetPublicMessage(msg string) HTTPError
+
+	// Errors gets the children of an HTTPError built with Combine, or nil if
+	// this error was not built with Combine
+	Errors() []error
 }
 
 type baseHTTPError struct {
-	msg       string
-	respCode  int
-	errCode   string
-	stack     string
-	inner     error
-	retriable bool
+	msg          string
+	respCode     int
+	errCode      string
+	frames       []Frame
+	inner        error
+	retriable    bool
+	retriableSet bool
+	publicMsg    string
+	hasPublicMsg bool
+	children     []error
 }
 
 const (
@@ -174,31 +195,17 @@ func (e *baseHTTPError) ErrorCode() string {
 	return codeErr.errCode
 }
 
-// StackTrace gets the innermost available stacktrace
-func (e *baseHTTPError) StackTrace() string {
-	var ok bool
-	var stackErr, nextStackErr *baseHTTPError
-	stackErr = e
-	for stackErr.inner != nil {
-		nextStackErr, ok = stackErr.inner.(*baseHTTPError)
-		if !ok {
-			return stackErr.stack
-		}
-		stackErr = nextStackErr
-	}
-	return stackErr.stack
-}
-
 // SetRetriable sets if this error is retriable
 func (e *baseHTTPError) SetRetriable(retriable bool) HTTPError {
 	e.retriable = retriable
+	e.retriableSet = true
 	return e
 }
 
 // Retriable checks if the error could be addressed by retrying the request. All
 // errors are considered retriable by default unless otherwise specified
 func (e *baseHTTPError) Retriable() bool {
-	if nil == e.inner {
+	if e.retriableSet || nil == e.inner {
 		return e.retriable
 	}
 	httpErr, ok := e.inner.(HTTPError)
@@ -208,11 +215,28 @@ func (e *baseHTTPError) Retriable() bool {
 	return httpErr.Retriable()
 }
 
+// SetPublicMessage sets a public-facing message that WriteError and MarshalJSON
+// use in place of the full message chain, so servers can hide the internal
+// message chain from external clients while still logging it in full
+func (e *baseHTTPError) SetPublicMessage(msg string) HTTPError {
+	e.publicMsg = msg
+	e.hasPublicMsg = true
+	return e
+}
+
+// Errors gets the children of an HTTPError built with Combine, or nil if this
+// error was not built with Combine
+func (e *baseHTTPError) Errors() []error {
+	return e.children
+}
+
 /********************************************************************************
 * HTTPError instantiation functions
 ********************************************************************************/
 
-// Wrap takes an existing error and turns it into a HTTPError
+// Wrap takes an existing error and turns it into a HTTPError. The wrapped
+// error remains reachable via Unwrap, so errors.Is and errors.As work across
+// the whole wrap chain (e.g. errors.Is(Wrap(sql.ErrNoRows, "user 42"), sql.ErrNoRows)).
 func Wrap(err error, msg string) HTTPError {
 	if err == nil {
 		return nil
@@ -224,14 +248,16 @@ func Wrap(err error, msg string) HTTPError {
 		inner:    err,
 	}
 
-	// Wrap will only get a new stack trace if one does not exist
+	// Wrap will only get new frames if none exist yet
 	if _, ok := err.(*baseHTTPError); !ok {
-		resp.stack = stackTrace()
+		resp.frames = captureFrames(callersToSkip)
 	}
 	return &resp
 }
 
-// Wrapf wraps an existing error with printf paramaters
+// Wrapf wraps an existing error with printf paramaters. Like Wrap, the wrapped
+// error remains reachable via Unwrap, so errors.Is and errors.As work across
+// the whole wrap chain.
 func Wrapf(err error, format string, args ...interface{}) HTTPError {
 	if err == nil {
 		return nil
@@ -243,9 +269,9 @@ func Wrapf(err error, format string, args ...interface{}) HTTPError {
 		inner:    err,
 	}
 
-	// Wrap will only get a new stack trace if one does not exist
+	// Wrap will only get new frames if none exist yet
 	if _, ok := err.(*baseHTTPError); !ok {
-		resp.stack = stackTrace()
+		resp.frames = captureFrames(callersToSkip)
 	}
 	return &resp
 }
@@ -255,7 +281,7 @@ func New(msg string) HTTPError {
 	return &baseHTTPError{
 		msg:       msg,
 		respCode:  UninitializedResponseCode,
-		stack:     stackTrace(),
+		frames:    captureFrames(callersToSkip),
 		retriable: true,
 	}
 }
@@ -265,7 +291,7 @@ func Newf(format string, args ...interface{}) HTTPError {
 	return &baseHTTPError{
 		msg:       fmt.Sprintf(format, args...),
 		respCode:  UninitializedResponseCode,
-		stack:     stackTrace(),
+		frames:    captureFrames(callersToSkip),
 		retriable: true,
 	}
 }
@@ -283,7 +309,6 @@ func ToHTTPError(err error) HTTPError {
 			msg:       "",
 			respCode:  UninitializedResponseCode,
 			inner:     err,
-			stack:     UninitializedStackTrace,
 			retriable: true,
 		}
 	}
@@ -314,21 +339,3 @@ func IsRetriableError(err error) bool {
 	httperr := ToHTTPError(err)
 	return httperr.Retriable()
 }
-
-// stackTrace returns the current stack trace
-func stackTrace() string {
-	buf := make([]byte, 2048)
-	bytesRead := 0
-	for {
-		bytesRead = runtime.Stack(buf, false)
-		if bytesRead < len(buf) {
-			break
-		}
-		buf = make([]byte, len(buf)*2)
-	}
-
-	// split stack trace to remove lines inside httperrors
-	lines := strings.Split(string(buf[:bytesRead]), "\n")
-	trimmedLines := append(lines[:1], lines[5:]...)
-	return strings.Join(trimmedLines, "\n")
-}
@@ -0,0 +1,13 @@
+package httperrors
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestHTTPErrors(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HTTPErrors Suite")
+}
@@ -0,0 +1,88 @@
+package httperrors
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// errorJSON is the wire format used by MarshalJSON/UnmarshalJSON and WriteError.
+type errorJSON struct {
+	Status  int      `json:"status"`
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The resulting JSON has the shape
+// {"status":<int>,"code":"<errCode>","message":"<Message()>","details":[<inner messages...>]}.
+// If SetPublicMessage was called, the public message is used in place of
+// Message() and the inner message chain is omitted from details.
+func (e *baseHTTPError) MarshalJSON() ([]byte, error) {
+	respCode := e.ResponseCode()
+	if respCode == UninitializedResponseCode {
+		respCode = http.StatusInternalServerError
+	}
+
+	message := e.Message()
+	var details []string
+	if e.hasPublicMsg {
+		message = e.publicMsg
+	} else if lines := strings.Split(e.Error(), "\n"); len(lines) > 1 {
+		details = lines[1:]
+	}
+
+	return json.Marshal(errorJSON{
+		Status:  respCode,
+		Code:    e.ErrorCode(),
+		Message: message,
+		Details: details,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reconstructing the response code,
+// error code, and inner message chain from the shape produced by MarshalJSON.
+func (e *baseHTTPError) UnmarshalJSON(data []byte) error {
+	var parsed errorJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+
+	e.msg = parsed.Message
+	e.respCode = parsed.Status
+	e.errCode = parsed.Code
+	e.retriable = true
+
+	var inner error
+	for i := len(parsed.Details) - 1; i >= 0; i-- {
+		inner = &baseHTTPError{
+			msg:       parsed.Details[i],
+			respCode:  UninitializedResponseCode,
+			retriable: true,
+			inner:     inner,
+		}
+	}
+	e.inner = inner
+	return nil
+}
+
+// UnmarshalHTTPError decodes JSON produced by MarshalJSON back into an
+// HTTPError, preserving ResponseCode, ErrorCode, and the inner-message chain.
+func UnmarshalHTTPError(data []byte) (HTTPError, error) {
+	e := &baseHTTPError{}
+	if err := e.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// WriteError inspects err, coerces it via ToHTTPError, and writes it to w as a
+// JSON error response with the matching HTTP status code.
+func WriteError(w http.ResponseWriter, err error) {
+	httpErr := ToHTTPError(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPResponseCodeFromError(httpErr))
+
+	body, _ := json.Marshal(httpErr)
+	w.Write(body)
+}
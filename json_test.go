@@ -0,0 +1,80 @@
+package httperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSON", func() {
+	Describe("MarshalJSON", func() {
+		It("serializes status, code, message and details", func() {
+			err := Wrap(NotFound("user 42"), "could not load profile")
+
+			data, marshalErr := json.Marshal(err)
+			Expect(marshalErr).ToNot(HaveOccurred())
+
+			var decoded map[string]interface{}
+			Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+			Expect(decoded["status"]).To(Equal(float64(http.StatusNotFound)))
+			Expect(decoded["code"]).To(Equal("not_found"))
+			Expect(decoded["message"]).To(Equal("could not load profile"))
+			Expect(decoded["details"]).To(Equal([]interface{}{"user 42"}))
+		})
+
+		It("hides the message chain behind a public message when set", func() {
+			err := Wrap(NotFound("user 42"), "could not load profile")
+			err.SetPublicMessage("profile unavailable")
+
+			data, marshalErr := json.Marshal(err)
+			Expect(marshalErr).ToNot(HaveOccurred())
+
+			var decoded map[string]interface{}
+			Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+			Expect(decoded["message"]).To(Equal("profile unavailable"))
+			Expect(decoded).ToNot(HaveKey("details"))
+		})
+	})
+
+	Describe("UnmarshalHTTPError", func() {
+		It("round-trips response code, error code and the message chain", func() {
+			original := Wrap(NotFound("user 42"), "could not load profile")
+
+			data, marshalErr := json.Marshal(original)
+			Expect(marshalErr).ToNot(HaveOccurred())
+
+			decoded, unmarshalErr := UnmarshalHTTPError(data)
+			Expect(unmarshalErr).ToNot(HaveOccurred())
+			Expect(decoded.ResponseCode()).To(Equal(http.StatusNotFound))
+			Expect(decoded.ErrorCode()).To(Equal("not_found"))
+			Expect(decoded.Message()).To(Equal("could not load profile"))
+			Expect(decoded.InnerMessage()).To(Equal("user 42"))
+		})
+	})
+
+	Describe("WriteError", func() {
+		It("writes the response code and JSON body", func() {
+			recorder := httptest.NewRecorder()
+			WriteError(recorder, Conflict("duplicate entry"))
+
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+
+			var decoded map[string]interface{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &decoded)).To(Succeed())
+			Expect(decoded["code"]).To(Equal("conflict"))
+			Expect(decoded["message"]).To(Equal("duplicate entry"))
+		})
+
+		It("defaults to 500 for a plain error", func() {
+			recorder := httptest.NewRecorder()
+			WriteError(recorder, errors.New("boom"))
+
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
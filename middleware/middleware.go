@@ -0,0 +1,114 @@
+// Package middleware provides http.Handler adapters that map httperrors.HTTPError
+// values (and recovered panics) to JSON responses.
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+
+	"github.com/nickmarrone/httperrors"
+)
+
+// panicFramesToSkip skips runtime.Callers, capturePanicFrames, the deferred
+// closure in Recover that calls it, and runtime.gopanic, so the first
+// captured frame is the function that actually panicked
+const panicFramesToSkip = 4
+
+// ErrorLogger lets applications wire an HTTPError's stack trace and inner
+// message chain into their logger of choice, without this package importing
+// any specific logging library.
+type ErrorLogger interface {
+	LogError(ctx context.Context, err httperrors.HTTPError)
+}
+
+type noopLogger struct{}
+
+func (noopLogger) LogError(ctx context.Context, err httperrors.HTTPError) {}
+
+// Logger is used by Recover and HandlerFunc to report errors. It defaults to
+// a no-op logger; call SetLogger to wire it into your own logging system.
+var Logger ErrorLogger = noopLogger{}
+
+// SetLogger sets the ErrorLogger used by Recover and HandlerFunc
+func SetLogger(logger ErrorLogger) {
+	Logger = logger
+}
+
+// Recover returns middleware that recovers panics from next, converts them
+// into an HTTPError capturing a stack trace at the panic site, logs them via
+// Logger, and writes them as a JSON error response using httperrors.WriteError.
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				httpErr := recoveredToHTTPError(recovered, capturePanicFrames(panicFramesToSkip))
+				Logger.LogError(r.Context(), httpErr)
+				httperrors.WriteError(w, httpErr)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// capturePanicFrames walks the call stack using runtime.Callers/runtime.CallersFrames,
+// skipping the given number of frames from the top of the stack. It is called
+// directly from Recover's deferred closure so that skip can account for the
+// fixed number of frames runtime.gopanic and the closure itself add on top of
+// the panic site.
+func capturePanicFrames(skip int) []httperrors.Frame {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	callerFrames := runtime.CallersFrames(pcs[:n])
+	frames := make([]httperrors.Frame, 0, n)
+	for {
+		callerFrame, more := callerFrames.Next()
+		frames = append(frames, httperrors.Frame{
+			Func: callerFrame.Function,
+			File: callerFrame.File,
+			Line: callerFrame.Line,
+		})
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// HandlerFunc adapts a handler function that returns an error into an
+// http.Handler. A non-nil error is logged via Logger and written as a JSON
+// error response using httperrors.WriteError, so handlers can simply
+// `return httperrors.NotFound("user")` instead of writing status and body
+// themselves.
+func HandlerFunc(fn func(http.ResponseWriter, *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(w, r); err != nil {
+			httpErr := httperrors.ToHTTPError(err)
+			Logger.LogError(r.Context(), httpErr)
+			httperrors.WriteError(w, httpErr)
+		}
+	})
+}
+
+// recoveredToHTTPError converts a recovered panic value into an HTTPError,
+// defaulting to a 500 response code. frames, captured at the panic site by
+// the caller, are attached unless the panic value was already an HTTPError
+// (which carries its own frames from where it was originally constructed).
+func recoveredToHTTPError(recovered interface{}, frames []httperrors.Frame) httperrors.HTTPError {
+	switch v := recovered.(type) {
+	case httperrors.HTTPError:
+		return v
+	case error:
+		return httperrors.Wrap(v, "panic recovered").
+			SetResponseCode(http.StatusInternalServerError).
+			SetFrames(frames)
+	default:
+		return httperrors.Newf("panic recovered: %v", v).
+			SetResponseCode(http.StatusInternalServerError).
+			SetFrames(frames)
+	}
+}
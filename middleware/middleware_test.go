@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/nickmarrone/httperrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordingLogger struct {
+	errs []httperrors.HTTPError
+}
+
+func (l *recordingLogger) LogError(ctx context.Context, err httperrors.HTTPError) {
+	l.errs = append(l.errs, err)
+}
+
+var _ = Describe("Recover", func() {
+	var logger *recordingLogger
+
+	BeforeEach(func() {
+		logger = &recordingLogger{}
+		SetLogger(logger)
+	})
+
+	AfterEach(func() {
+		SetLogger(noopLogger{})
+	})
+
+	It("converts a panic into a JSON error response", func() {
+		handler := Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(httperrors.Conflict("duplicate entry"))
+		}))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusConflict))
+
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &decoded)).To(Succeed())
+		Expect(decoded["code"]).To(Equal("conflict"))
+		Expect(logger.errs).To(HaveLen(1))
+	})
+
+	It("captures the frames of the function that actually panicked", func() {
+		handler := Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deepPanic(errors.New("boom"))
+		}))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		Expect(logger.errs).To(HaveLen(1))
+		frames := logger.errs[0].Frames()
+		Expect(frames).ToNot(BeEmpty())
+		Expect(frames[0].Func).To(ContainSubstring("deepPanic"))
+		Expect(frames[0].Func).ToNot(ContainSubstring("recoveredToHTTPError"))
+		Expect(strings.Contains(frames[0].Func, "capturePanicFrames")).To(BeFalse())
+	})
+
+	It("defaults to a 500 for a panic with a plain value", func() {
+		handler := Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("something went wrong")
+		}))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+		Expect(logger.errs).To(HaveLen(1))
+	})
+
+	It("does not interfere when the handler does not panic", func() {
+		handler := Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(logger.errs).To(BeEmpty())
+	})
+})
+
+var _ = Describe("HandlerFunc", func() {
+	var logger *recordingLogger
+
+	BeforeEach(func() {
+		logger = &recordingLogger{}
+		SetLogger(logger)
+	})
+
+	AfterEach(func() {
+		SetLogger(noopLogger{})
+	})
+
+	It("writes the returned error as a JSON response", func() {
+		handler := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return httperrors.NotFound("user 42")
+		})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		Expect(logger.errs).To(HaveLen(1))
+	})
+
+	It("does nothing when the handler returns nil", func() {
+		handler := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusCreated)
+			return nil
+		})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusCreated))
+		Expect(logger.errs).To(BeEmpty())
+	})
+})
+
+// deepPanic exists purely so tests can assert that Recover captures frames at
+// the actual panic site rather than somewhere inside this package
+func deepPanic(err error) {
+	panic(err)
+}
@@ -0,0 +1,95 @@
+package httperrors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// maxFrames bounds how many program counters are collected per captured stack
+const maxFrames = 32
+
+// callersToSkip skips runtime.Callers, captureFrames, and the httperrors
+// constructor that calls it, so the first captured frame is the caller's
+// actual call site rather than a frame inside this package
+const callersToSkip = 3
+
+// Frame describes a single stack frame captured at the point an HTTPError
+// was created
+type Frame struct {
+	// Func is the fully qualified function name, e.g. "github.com/foo/bar.DoThing"
+	Func string
+
+	// File is the absolute path to the source file containing the call
+	File string
+
+	// Line is the line number within File
+	Line int
+}
+
+// captureFrames walks the call stack using runtime.Callers/runtime.CallersFrames,
+// skipping the given number of frames from the top of the stack
+func captureFrames(skip int) []Frame {
+	pcs := make([]uintptr, maxFrames)
+	n := runtime.Callers(skip, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	callerFrames := runtime.CallersFrames(pcs[:n])
+	frames := make([]Frame, 0, n)
+	for {
+		callerFrame, more := callerFrames.Next()
+		frames = append(frames, Frame{
+			Func: callerFrame.Function,
+			File: callerFrame.File,
+			Line: callerFrame.Line,
+		})
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// SetFrames overrides the stack frames recorded for this HTTPError
+func (e *baseHTTPError) SetFrames(frames []Frame) HTTPError {
+	e.frames = frames
+	return e
+}
+
+// Frames gets the frames of the outermost error in the wrap chain that recorded
+// any. Wrap records none when wrapping an HTTPError, so for a plain wrap chain
+// these are the frames of the innermost HTTPError. A node's own frames win
+// as soon as they're non-empty, so a node that captured its own frames (e.g.
+// Combine, which captures at the point errors are aggregated) is not masked
+// by an inner chain built for other purposes (e.g. Combine's per-child
+// message chain) that never captured any.
+func (e *baseHTTPError) Frames() []Frame {
+	var ok bool
+	var frameErr, nextFrameErr *baseHTTPError
+	frameErr = e
+	for len(frameErr.frames) == 0 && frameErr.inner != nil {
+		nextFrameErr, ok = frameErr.inner.(*baseHTTPError)
+		if !ok {
+			return frameErr.frames
+		}
+		frameErr = nextFrameErr
+	}
+	return frameErr.frames
+}
+
+// StackTrace formats the frames returned by Frames as a string.
+// Kept for backward compatibility; prefer Frames for programmatic inspection
+func (e *baseHTTPError) StackTrace() string {
+	frames := e.Frames()
+	if len(frames) == 0 {
+		return UninitializedStackTrace
+	}
+
+	lines := make([]string, len(frames))
+	for i, frame := range frames {
+		lines[i] = fmt.Sprintf("%s\n\t%s:%d", frame.Func, frame.File, frame.Line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,46 @@
+package httperrors
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Frames", func() {
+	It("captures the call site for a New error", func() {
+		httpErr := New("test err")
+		frames := httpErr.Frames()
+		Expect(frames).ToNot(BeEmpty())
+		Expect(frames[0].Func).To(ContainSubstring("httperrors"))
+		Expect(frames[0].File).ToNot(ContainSubstring("httperrors/stack.go"))
+		Expect(frames[0].File).ToNot(ContainSubstring("httperrors/httperrors.go"))
+	})
+
+	It("captures the call site for a Wrap error", func() {
+		err := fmt.Errorf("base err")
+		httpErr := Wrap(err, "test err")
+		frames := httpErr.Frames()
+		Expect(frames).ToNot(BeEmpty())
+		Expect(frames[0].File).ToNot(ContainSubstring("httperrors/httperrors.go"))
+	})
+
+	It("is empty for an error cast from a standard error", func() {
+		castErr := ToHTTPError(fmt.Errorf("test err"))
+		Expect(castErr.Frames()).To(BeEmpty())
+	})
+
+	Describe("StackTrace", func() {
+		It("formats the frames as a multi-line string", func() {
+			httpErr := New("test err")
+			lines := strings.Split(httpErr.StackTrace(), "\n")
+			Expect(len(lines)).To(BeNumerically(">=", 2))
+		})
+
+		It("returns UninitializedStackTrace when there are no frames", func() {
+			castErr := ToHTTPError(fmt.Errorf("test err"))
+			Expect(castErr.StackTrace()).To(Equal(UninitializedStackTrace))
+		})
+	})
+})
@@ -0,0 +1,187 @@
+package httperrors
+
+import "net/http"
+
+/********************************************************************************
+* Typed HTTP status constructors
+*
+* Each constructor below builds an HTTPError pre-populated with the matching
+* response code and a canonical, greppable error code. They exist to remove
+* the boilerplate of New(...).SetResponseCode(http.StatusX) at every call site.
+********************************************************************************/
+
+// BadRequest creates an HTTPError with a 400 Bad Request response code
+func BadRequest(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusBadRequest).SetErrorCode("bad_request")
+}
+
+// Unauthorized creates an HTTPError with a 401 Unauthorized response code
+func Unauthorized(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusUnauthorized).SetErrorCode("unauthorized")
+}
+
+// Forbidden creates an HTTPError with a 403 Forbidden response code
+func Forbidden(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusForbidden).SetErrorCode("forbidden")
+}
+
+// NotFound creates an HTTPError with a 404 Not Found response code
+func NotFound(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusNotFound).SetErrorCode("not_found")
+}
+
+// MethodNotAllowed creates an HTTPError with a 405 Method Not Allowed response code
+func MethodNotAllowed(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusMethodNotAllowed).SetErrorCode("method_not_allowed")
+}
+
+// NotAcceptable creates an HTTPError with a 406 Not Acceptable response code
+func NotAcceptable(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusNotAcceptable).SetErrorCode("not_acceptable")
+}
+
+// Conflict creates an HTTPError with a 409 Conflict response code
+func Conflict(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusConflict).SetErrorCode("conflict")
+}
+
+// Gone creates an HTTPError with a 410 Gone response code
+func Gone(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusGone).SetErrorCode("gone")
+}
+
+// PreconditionFailed creates an HTTPError with a 412 Precondition Failed response code
+func PreconditionFailed(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusPreconditionFailed).SetErrorCode("precondition_failed")
+}
+
+// PayloadTooLarge creates an HTTPError with a 413 Payload Too Large response code
+func PayloadTooLarge(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusRequestEntityTooLarge).SetErrorCode("payload_too_large")
+}
+
+// UnsupportedMediaType creates an HTTPError with a 415 Unsupported Media Type response code
+func UnsupportedMediaType(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusUnsupportedMediaType).SetErrorCode("unsupported_media_type")
+}
+
+// UnprocessableEntity creates an HTTPError with a 422 Unprocessable Entity response code
+func UnprocessableEntity(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusUnprocessableEntity).SetErrorCode("unprocessable_entity")
+}
+
+// TooManyRequests creates an HTTPError with a 429 Too Many Requests response code
+func TooManyRequests(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusTooManyRequests).SetErrorCode("too_many_requests")
+}
+
+// InternalServerError creates an HTTPError with a 500 Internal Server Error response code
+func InternalServerError(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusInternalServerError).SetErrorCode("internal_server_error")
+}
+
+// NotImplemented creates an HTTPError with a 501 Not Implemented response code
+func NotImplemented(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusNotImplemented).SetErrorCode("not_implemented")
+}
+
+// BadGateway creates an HTTPError with a 502 Bad Gateway response code
+func BadGateway(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusBadGateway).SetErrorCode("bad_gateway")
+}
+
+// ServiceUnavailable creates an HTTPError with a 503 Service Unavailable response code
+func ServiceUnavailable(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusServiceUnavailable).SetErrorCode("service_unavailable")
+}
+
+// GatewayTimeout creates an HTTPError with a 504 Gateway Timeout response code
+func GatewayTimeout(msg string) HTTPError {
+	return New(msg).SetResponseCode(http.StatusGatewayTimeout).SetErrorCode("gateway_timeout")
+}
+
+/********************************************************************************
+* Sentinel errors
+*
+* These can be wrapped with Wrap/Wrapf (e.g. Wrap(ErrNotFound, "user 42")) and
+* later matched with Is, regardless of how many layers of wrapping sit on top.
+********************************************************************************/
+
+var (
+	// ErrBadRequest is a sentinel error matching a 400 Bad Request
+	ErrBadRequest = BadRequest("bad request")
+
+	// ErrUnauthorized is a sentinel error matching a 401 Unauthorized
+	ErrUnauthorized = Unauthorized("unauthorized")
+
+	// ErrForbidden is a sentinel error matching a 403 Forbidden
+	ErrForbidden = Forbidden("forbidden")
+
+	// ErrNotFound is a sentinel error matching a 404 Not Found
+	ErrNotFound = NotFound("not found")
+
+	// ErrMethodNotAllowed is a sentinel error matching a 405 Method Not Allowed
+	ErrMethodNotAllowed = MethodNotAllowed("method not allowed")
+
+	// ErrNotAcceptable is a sentinel error matching a 406 Not Acceptable
+	ErrNotAcceptable = NotAcceptable("not acceptable")
+
+	// ErrConflict is a sentinel error matching a 409 Conflict
+	ErrConflict = Conflict("conflict")
+
+	// ErrGone is a sentinel error matching a 410 Gone
+	ErrGone = Gone("gone")
+
+	// ErrPreconditionFailed is a sentinel error matching a 412 Precondition Failed
+	ErrPreconditionFailed = PreconditionFailed("precondition failed")
+
+	// ErrPayloadTooLarge is a sentinel error matching a 413 Payload Too Large
+	ErrPayloadTooLarge = PayloadTooLarge("payload too large")
+
+	// ErrUnsupportedMediaType is a sentinel error matching a 415 Unsupported Media Type
+	ErrUnsupportedMediaType = UnsupportedMediaType("unsupported media type")
+
+	// ErrUnprocessableEntity is a sentinel error matching a 422 Unprocessable Entity
+	ErrUnprocessableEntity = UnprocessableEntity("unprocessable entity")
+
+	// ErrTooManyRequests is a sentinel error matching a 429 Too Many Requests
+	ErrTooManyRequests = TooManyRequests("too many requests")
+
+	// ErrInternalServerError is a sentinel error matching a 500 Internal Server Error
+	ErrInternalServerError = InternalServerError("internal server error")
+
+	// ErrNotImplemented is a sentinel error matching a 501 Not Implemented
+	ErrNotImplemented = NotImplemented("not implemented")
+
+	// ErrBadGateway is a sentinel error matching a 502 Bad Gateway
+	ErrBadGateway = BadGateway("bad gateway")
+
+	// ErrServiceUnavailable is a sentinel error matching a 503 Service Unavailable
+	ErrServiceUnavailable = ServiceUnavailable("service unavailable")
+
+	// ErrGatewayTimeout is a sentinel error matching a 504 Gateway Timeout
+	ErrGatewayTimeout = GatewayTimeout("gateway timeout")
+)
+
+// Is reports whether err matches target. Unlike a plain equality check, this
+// compares on ResponseCode and ErrorCode, so a wrapped sentinel (e.g.
+// Wrap(ErrNotFound, "user 42")) still matches its sentinel after being wrapped.
+func Is(err, target error) bool {
+	if err == nil || target == nil {
+		return err == target
+	}
+
+	targetHTTPErr, ok := target.(HTTPError)
+	if !ok {
+		return err == target
+	}
+
+	httpErr, ok := err.(HTTPError)
+	if !ok {
+		return err == target
+	}
+
+	return httpErr.ErrorCode() != UninitializedErrorCode &&
+		httpErr.ErrorCode() == targetHTTPErr.ErrorCode() &&
+		httpErr.ResponseCode() == targetHTTPErr.ResponseCode()
+}
@@ -0,0 +1,52 @@
+package httperrors
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Typed status constructors", func() {
+	It("pre-populates the response code and error code", func() {
+		err := BadRequest("missing field")
+		Expect(err.ResponseCode()).To(Equal(http.StatusBadRequest))
+		Expect(err.ErrorCode()).To(Equal("bad_request"))
+		Expect(err.Message()).To(Equal("missing field"))
+	})
+
+	It("covers the rest of the standard status classes", func() {
+		Expect(Unauthorized("x").ResponseCode()).To(Equal(http.StatusUnauthorized))
+		Expect(Forbidden("x").ResponseCode()).To(Equal(http.StatusForbidden))
+		Expect(NotFound("x").ResponseCode()).To(Equal(http.StatusNotFound))
+		Expect(Conflict("x").ResponseCode()).To(Equal(http.StatusConflict))
+		Expect(TooManyRequests("x").ResponseCode()).To(Equal(http.StatusTooManyRequests))
+		Expect(InternalServerError("x").ResponseCode()).To(Equal(http.StatusInternalServerError))
+		Expect(BadGateway("x").ResponseCode()).To(Equal(http.StatusBadGateway))
+		Expect(ServiceUnavailable("x").ResponseCode()).To(Equal(http.StatusServiceUnavailable))
+	})
+})
+
+var _ = Describe("Sentinel errors", func() {
+	Describe("Is", func() {
+		It("matches a sentinel wrapped once", func() {
+			err := Wrap(ErrNotFound, "user 42")
+			Expect(Is(err, ErrNotFound)).To(BeTrue())
+		})
+
+		It("matches a sentinel wrapped multiple times", func() {
+			err := Wrap(Wrap(ErrConflict, "retrying"), "could not update user")
+			Expect(Is(err, ErrConflict)).To(BeTrue())
+		})
+
+		It("does not match a different sentinel", func() {
+			err := Wrap(ErrNotFound, "user 42")
+			Expect(Is(err, ErrForbidden)).To(BeFalse())
+		})
+
+		It("does not match a plain error without a response code", func() {
+			err := New("plain")
+			Expect(Is(err, ErrNotFound)).To(BeFalse())
+		})
+	})
+})
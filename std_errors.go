@@ -0,0 +1,43 @@
+package httperrors
+
+import "errors"
+
+// Unwrap returns the wrapped error, allowing the standard library's
+// errors.Unwrap, errors.Is and errors.As to traverse the wrap chain built up
+// by Wrap/Wrapf.
+func (e *baseHTTPError) Unwrap() error {
+	return e.inner
+}
+
+// Is implements the errors.Is extension point, so errors.Is(err, target) walks
+// the wrap chain and defers to the package-level Is, matching on ResponseCode
+// and ErrorCode when target is an HTTPError (e.g. a sentinel like ErrNotFound),
+// and falling back to plain equality otherwise. For an error built with
+// Combine, each child is also checked.
+func (e *baseHTTPError) Is(target error) bool {
+	if Is(e, target) {
+		return true
+	}
+	for _, child := range e.children {
+		if errors.Is(child, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As implements the errors.As extension point, so errors.As(err, &httpErr)
+// assigns the first HTTPError found while walking the wrap chain. For an
+// error built with Combine, each child is also checked.
+func (e *baseHTTPError) As(target interface{}) bool {
+	if httpErrTarget, ok := target.(*HTTPError); ok {
+		*httpErrTarget = e
+		return true
+	}
+	for _, child := range e.children {
+		if errors.As(child, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package httperrors
+
+import (
+	"database/sql"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Standard library error composition", func() {
+	Describe("errors.Is", func() {
+		It("finds a plain wrapped error across the wrap chain", func() {
+			wrapped := Wrap(Wrap(sql.ErrNoRows, "loading user"), "handling request")
+			Expect(errors.Is(wrapped, sql.ErrNoRows)).To(BeTrue())
+		})
+
+		It("finds a wrapped sentinel across the wrap chain", func() {
+			wrapped := Wrap(ErrNotFound, "user 42")
+			Expect(errors.Is(wrapped, ErrNotFound)).To(BeTrue())
+			Expect(errors.Is(wrapped, ErrForbidden)).To(BeFalse())
+		})
+	})
+
+	Describe("errors.As", func() {
+		It("extracts an HTTPError across the wrap chain", func() {
+			wrapped := Wrap(Wrap(sql.ErrNoRows, "loading user"), "handling request")
+
+			var httpErr HTTPError
+			Expect(errors.As(wrapped, &httpErr)).To(BeTrue())
+			Expect(httpErr.Message()).To(Equal("handling request"))
+		})
+	})
+
+	Describe("Unwrap", func() {
+		It("returns the next error in the chain", func() {
+			inner := errors.New("base err")
+			wrapped := Wrap(inner, "outer err")
+			Expect(errors.Unwrap(wrapped)).To(Equal(inner))
+		})
+	})
+})